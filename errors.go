@@ -15,19 +15,38 @@
 package errors
 
 import (
+	stderrors "errors"
 	"reflect"
 
 	"github.com/goentf/runpoint"
 )
 
 type errorChain struct {
-	pc   runpoint.PCounter
-	text string
-	next error
+	pc     runpoint.PCounter
+	text   string
+	next   error
+	stack  []uintptr
+	kind   Kind
+	fields []field
 }
 
+// field is a single structured key/value pair attached to an error,
+// kept in an ordered slice so With/WithFields have predictable output.
+type field struct {
+	key   string
+	value any
+}
+
+// Error renders the same "outer: inner: root" chain that %s and %v
+// print, so callers logging via Error() see the same thing fmt does.
 func (e *errorChain) Error() string {
-	return e.text
+	return chainText(e)
+}
+
+// Unwrap returns the underlying cause of e, allowing the stdlib
+// errors.Is, errors.As and errors.Unwrap to traverse the chain.
+func (e *errorChain) Unwrap() error {
+	return e.next
 }
 
 // New returns an error in the format of the given text.
@@ -40,9 +59,10 @@ func New(text string, cause ...error) error {
 		e = cause[0]
 	}
 	return &errorChain{
-		text: text,
-		next: e,
-		pc:   runpoint.PC(1),
+		text:  text,
+		next:  e,
+		pc:    runpoint.PC(1),
+		stack: captureStack(2),
 	}
 }
 
@@ -82,51 +102,97 @@ func PC(err error) (pc runpoint.PCounter) {
 	return e.pc
 }
 
-// Cause returns the underlay error of err, if err's
-// type is *errorChain returning error. Otherwise, returns nil.
+// Cause returns the underlay error of err, if err itself implements
+// Unwrap() error. Otherwise, returns nil.
 func Cause(err error) error {
 	if err == nil {
 		return nil
 	}
-	ec, ok := err.(*errorChain)
+	u, ok := err.(interface{ Unwrap() error })
 	if !ok {
 		return nil
 	}
-	return ec.next
+	return u.Unwrap()
 }
 
-// ForCauses gets all errors on the error chain.
+// ForCauses calls fun for err and every error reachable from it by
+// following Unwrap() error and Unwrap() []error links, so chains built
+// from this package, fmt.Errorf("...: %w", err) and Join all work. Each
+// comparable error is visited at most once, so a chain that revisits
+// the same error through two branches does not loop forever; errors of
+// non-comparable types are not tracked and are visited each time they
+// are reached.
 func ForCauses(err error, fun func(error)) {
+	forCauses(err, fun, make(map[error]struct{}))
+}
+
+func forCauses(err error, fun func(error), visited map[error]struct{}) {
 	if err == nil {
 		return
 	}
-	for {
-		fun(err)
-		// Find the next error
-		if e, ok := err.(*errorChain); ok && e.next != nil {
-			err = e.next
-		} else {
+	if reflect.TypeOf(err).Comparable() {
+		if _, ok := visited[err]; ok {
 			return
 		}
+		visited[err] = struct{}{}
+	}
+	fun(err)
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, next := range e.Unwrap() {
+			forCauses(next, fun, visited)
+		}
+	case interface{ Unwrap() error }:
+		forCauses(e.Unwrap(), fun, visited)
 	}
 }
 
-// OneCauseOf is used to determine whether
-// the cause of the specified error is the target error.
+// OneCauseOf reports whether target is found in err's chain:
+// by comparable equality, by a chain link's Is(target) method (both
+// via stdlib errors.Is semantics), or by any link sharing target's
+// concrete type (As-style type matching). The As-style match is
+// skipped when target's type is this package's own generic wrapper
+// (*errorChain or *multiError), since virtually every error the
+// package constructs shares one of those two types and matching on
+// type alone would equate unrelated sentinels.
 func OneCauseOf(err error, target error) bool {
 	if target == nil {
 		return err == target
 	}
-
-	isComparable := reflect.TypeOf(target).Comparable()
-	for {
-		if isComparable && err == target {
-			return true
-		}
-		if ec, ok := err.(*errorChain); ok && ec.next != nil {
-			err = ec.next
-		} else {
-			return false
-		}
+	if stderrors.Is(err, target) {
+		return true
+	}
+	targetType := reflect.TypeOf(target)
+	switch targetType {
+	case reflect.TypeOf((*errorChain)(nil)), reflect.TypeOf((*multiError)(nil)):
+		return false
 	}
+	sameType := false
+	ForCauses(err, func(e error) {
+		if !sameType && reflect.TypeOf(e) == targetType {
+			sameType = true
+		}
+	})
+	return sameType
+}
+
+// Is reports whether any error in err's chain matches target, following
+// the same semantics as the stdlib errors.Is. It is provided so callers
+// that use this package exclusively don't also need to import "errors".
+func Is(err, target error) bool {
+	return stderrors.Is(err, target)
+}
+
+// As finds the first error in err's chain that matches target, and if
+// one is found, sets target to that error value and returns true. See
+// the stdlib errors.As for the full matching rules.
+func As(err error, target any) bool {
+	return stderrors.As(err, target)
+}
+
+// Unwrap returns the result of calling the Unwrap method on err, if
+// err's type contains an Unwrap method returning error. Otherwise,
+// Unwrap returns nil.
+func Unwrap(err error) error {
+	return stderrors.Unwrap(err)
 }
@@ -15,6 +15,8 @@
 package errors_test
 
 import (
+	"fmt"
+	"io/fs"
 	"testing"
 
 	"github.com/goentf/errors"
@@ -27,3 +29,117 @@ func TestCause(t *testing.T) {
 		t.Errorf("Cause fail")
 	}
 }
+
+func TestUnwrap(t *testing.T) {
+	err1 := errors.New("unit error1")
+	err := errors.New("unit error2", err1)
+	if errors.Unwrap(err) != err1 {
+		t.Errorf("Unwrap fail")
+	}
+}
+
+func TestIsWrappedStdlibError(t *testing.T) {
+	err := errors.New("open config", fs.ErrNotExist)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Is should find fs.ErrNotExist in the chain")
+	}
+	if !errors.OneCauseOf(err, fs.ErrNotExist) {
+		t.Errorf("OneCauseOf should find fs.ErrNotExist in the chain")
+	}
+}
+
+func TestIsMixedChain(t *testing.T) {
+	sentinel := fmt.Errorf("sentinel")
+	wrapped := fmt.Errorf("stdlib wrap: %w", sentinel)
+	err := errors.New("package wrap", wrapped)
+	if !errors.Is(err, sentinel) {
+		t.Errorf("Is should traverse through a stdlib %%w chain embedded in this package's chain")
+	}
+}
+
+func TestAs(t *testing.T) {
+	var pathErr *fs.PathError
+	err := errors.New("open config", &fs.PathError{Op: "open", Path: "config.yaml", Err: fs.ErrNotExist})
+	if !errors.As(err, &pathErr) {
+		t.Errorf("As should find the *fs.PathError in the chain")
+	}
+	if pathErr.Path != "config.yaml" {
+		t.Errorf("As set target to the wrong error: %v", pathErr)
+	}
+}
+
+// sliceErr holds a slice field, so the type is non-comparable (a == on
+// two error interfaces holding sliceErr values panics) and it must be
+// matched via Is instead.
+type sliceErr struct {
+	tags []string
+}
+
+func (e sliceErr) Error() string { return "sliceErr" }
+
+func (e sliceErr) Is(target error) bool {
+	_, ok := target.(sliceErr)
+	return ok
+}
+
+func TestOneCauseOfNonComparableTargetWithIs(t *testing.T) {
+	target := sliceErr{tags: []string{"a"}}
+	wrapped := errors.New("wrap", target)
+
+	if !errors.Is(wrapped, target) {
+		t.Fatalf("expected Is to match via the chain link's Is method")
+	}
+	if !errors.OneCauseOf(wrapped, target) {
+		t.Errorf("expected OneCauseOf to agree with Is for a non-comparable target that matches via Is")
+	}
+}
+
+// distinctErr is a plain error type with no Is method, used to check
+// OneCauseOf's As-style type matching: two distinct instances of the
+// same type should match by type even though they aren't equal.
+type distinctErr struct{ msg string }
+
+func (e *distinctErr) Error() string { return e.msg }
+
+func TestOneCauseOfMatchesByType(t *testing.T) {
+	wrapped := errors.New("wrap", &distinctErr{msg: "a"})
+	other := &distinctErr{msg: "b"}
+
+	if errors.Is(wrapped, other) {
+		t.Fatalf("sanity check failed: stdlib Is should not match different *distinctErr instances")
+	}
+	if !errors.OneCauseOf(wrapped, other) {
+		t.Errorf("expected OneCauseOf to match a different instance of the same concrete type")
+	}
+}
+
+// TestOneCauseOfIgnoresPackageWrapperType locks in that As-style type
+// matching does not kick in for the package's own generic wrapper
+// types (*errorChain, *multiError): virtually every error this package
+// constructs shares one of those two concrete types, so matching on
+// type alone would equate unrelated sentinels.
+func TestOneCauseOfIgnoresPackageWrapperType(t *testing.T) {
+	target := errors.New("totally unrelated sentinel")
+	err := errors.New("some other error that has nothing to do with target")
+
+	if errors.OneCauseOf(err, target) {
+		t.Errorf("expected OneCauseOf to not match unrelated *errorChain sentinels by type alone")
+	}
+}
+
+func TestForCausesMixedChain(t *testing.T) {
+	sentinel := fmt.Errorf("sentinel")
+	wrapped := fmt.Errorf("stdlib wrap: %w", sentinel)
+	err := errors.New("package wrap", wrapped)
+
+	var seen []error
+	errors.ForCauses(err, func(e error) {
+		seen = append(seen, e)
+	})
+	if len(seen) != 3 {
+		t.Fatalf("expected 3 errors in the chain, got %d", len(seen))
+	}
+	if seen[0] != err || seen[1] != wrapped || seen[2] != sentinel {
+		t.Errorf("ForCauses did not walk the mixed chain in order: %v", seen)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2022 Kami
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import (
+	"fmt"
+
+	"github.com/goentf/runpoint"
+)
+
+// With returns a copy of err carrying an additional key/value field,
+// for structured logging (see Fields and the zaperr subpackage). It
+// returns nil if err is nil.
+func With(err error, key string, value any) error {
+	if err == nil {
+		return nil
+	}
+	return &errorChain{
+		next:   err,
+		fields: []field{{key: key, value: value}},
+		pc:     runpoint.PC(1),
+		stack:  captureStack(2),
+	}
+}
+
+// WithFields is like With but takes an alternating key, value, key,
+// value, ... list so several fields can be attached at once.
+func WithFields(err error, kv ...any) error {
+	if err == nil {
+		return nil
+	}
+	if len(kv)%2 != 0 {
+		panic("errors: WithFields requires an even number of key/value arguments")
+	}
+	fields := make([]field, 0, len(kv)/2)
+	for i := 0; i < len(kv); i += 2 {
+		key, ok := kv[i].(string)
+		if !ok {
+			panic(fmt.Sprintf("errors: WithFields key at index %d must be a string, got %T", i, kv[i]))
+		}
+		fields = append(fields, field{key: key, value: kv[i+1]})
+	}
+	return &errorChain{
+		next:   err,
+		fields: fields,
+		pc:     runpoint.PC(1),
+		stack:  captureStack(2),
+	}
+}
+
+// OwnFields returns the structured fields attached directly at err's
+// link, ignoring the rest of the chain. Unlike Fields, it does not
+// merge fields from err's causes; it's meant for callers that walk the
+// chain themselves (e.g. zaperr's per-cause log entries) and need each
+// link to report only what it actually carries.
+func OwnFields(err error) map[string]any {
+	ec, ok := err.(*errorChain)
+	if !ok || len(ec.fields) == 0 {
+		return nil
+	}
+	out := make(map[string]any, len(ec.fields))
+	for _, f := range ec.fields {
+		out[f.key] = f.value
+	}
+	return out
+}
+
+// Fields merges the structured fields attached anywhere in err's chain
+// into a single map. Precedence follows ForCauses' depth-first visit
+// order: a link visited earlier wins over a same-named field from a
+// link visited later. For a plain (non-branching) chain that means an
+// outer link overrides an inner one; for a multiError from Join, it
+// additionally means an earlier branch overrides a same-named field
+// from a later branch.
+func Fields(err error) map[string]any {
+	var chain []error
+	ForCauses(err, func(e error) {
+		chain = append(chain, e)
+	})
+	out := make(map[string]any)
+	for i := len(chain) - 1; i >= 0; i-- {
+		ec, ok := chain[i].(*errorChain)
+		if !ok {
+			continue
+		}
+		for _, f := range ec.fields {
+			out[f.key] = f.value
+		}
+	}
+	return out
+}
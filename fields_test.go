@@ -0,0 +1,105 @@
+// Copyright 2022 Kami
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/goentf/errors"
+)
+
+func TestWithField(t *testing.T) {
+	err := errors.With(errors.New("boom"), "user", 42)
+	fields := errors.Fields(err)
+	if fields["user"] != 42 {
+		t.Errorf("expected field user=42, got %v", fields)
+	}
+}
+
+func TestWithFieldsNil(t *testing.T) {
+	if errors.With(nil, "k", "v") != nil {
+		t.Errorf("expected With(nil, ...) to return nil")
+	}
+	if errors.WithFields(nil, "k", "v") != nil {
+		t.Errorf("expected WithFields(nil, ...) to return nil")
+	}
+}
+
+func TestWithFieldsOddArgsPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected WithFields to panic on an odd number of arguments")
+		}
+	}()
+	errors.WithFields(errors.New("boom"), "k")
+}
+
+func TestFieldsMergeOuterWins(t *testing.T) {
+	inner := errors.With(errors.New("boom"), "code", 1)
+	outer := errors.With(errors.New("wrapped", inner), "code", 2)
+
+	fields := errors.Fields(outer)
+	if fields["code"] != 2 {
+		t.Errorf("expected outer field to win, got %v", fields["code"])
+	}
+}
+
+func TestFieldsMultiple(t *testing.T) {
+	err := errors.WithFields(errors.New("boom"), "a", 1, "b", 2)
+	fields := errors.Fields(err)
+	if fields["a"] != 1 || fields["b"] != 2 {
+		t.Errorf("expected both fields to be present, got %v", fields)
+	}
+}
+
+func TestWithFieldsNonStringKeyPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected WithFields to panic on a non-string key")
+		}
+	}()
+	errors.WithFields(errors.New("boom"), 123, "v")
+}
+
+func TestOwnFields(t *testing.T) {
+	inner := errors.With(errors.New("boom"), "code", 1)
+	outer := errors.With(errors.New("wrapped", inner), "scope", "outer")
+
+	if fields := errors.OwnFields(outer); fields["scope"] != "outer" || fields["code"] != nil {
+		t.Errorf("expected only outer's own field, got %v", fields)
+	}
+	if fields := errors.OwnFields(inner); fields["code"] != 1 || fields["scope"] != nil {
+		t.Errorf("expected only inner's own field, got %v", fields)
+	}
+}
+
+func TestOwnFieldsNoFields(t *testing.T) {
+	if fields := errors.OwnFields(errors.New("boom")); fields != nil {
+		t.Errorf("expected nil for a link with no fields, got %v", fields)
+	}
+	if fields := errors.OwnFields(nil); fields != nil {
+		t.Errorf("expected nil for a nil error, got %v", fields)
+	}
+}
+
+func TestFieldsMergeAcrossJoinBranches(t *testing.T) {
+	first := errors.With(errors.New("a"), "code", 1)
+	second := errors.With(errors.New("b"), "code", 2)
+
+	fields := errors.Fields(errors.Join(first, second))
+	if fields["code"] != 1 {
+		t.Errorf("expected the earlier-listed Join branch to win, got %v", fields["code"])
+	}
+}
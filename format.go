@@ -0,0 +1,175 @@
+// Copyright 2022 Kami
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+
+	"github.com/goentf/runpoint"
+)
+
+// MaxStackDepth is the maximum number of stack frames captured for each
+// error created by New, Wrap, Wrapf, Annotate and Annotatef. Set it to 0
+// to disable stack capture entirely (WithMessage never captures a stack,
+// regardless of this setting).
+var MaxStackDepth = 32
+
+// captureStack records up to MaxStackDepth frames above the caller at
+// the given skip depth. skip follows runtime.Callers conventions: 0
+// identifies captureStack itself.
+func captureStack(skip int) []uintptr {
+	if MaxStackDepth <= 0 {
+		return nil
+	}
+	pcs := make([]uintptr, MaxStackDepth)
+	n := runtime.Callers(skip+1, pcs)
+	return pcs[:n]
+}
+
+// Stack returns the stack frames captured when err was created, in
+// order from the call site outward. It returns nil if err is not from
+// this package or no stack was captured for it.
+func Stack(err error) []runtime.Frame {
+	e, ok := err.(*errorChain)
+	if !ok || len(e.stack) == 0 {
+		return nil
+	}
+	frames := runtime.CallersFrames(e.stack)
+	var out []runtime.Frame
+	for {
+		f, more := frames.Next()
+		out = append(out, f)
+		if !more {
+			break
+		}
+	}
+	return out
+}
+
+// StackTrace returns the PCounter of every link in err's chain, in
+// outer-to-inner order, giving the sequence of call sites that produced
+// the error. Links that are not from this package are skipped.
+func StackTrace(err error) []runpoint.PCounter {
+	var out []runpoint.PCounter
+	ForCauses(err, func(e error) {
+		if ec, ok := e.(*errorChain); ok {
+			out = append(out, ec.pc)
+		}
+	})
+	return out
+}
+
+// chainText renders the chain in the pkg/errors style: "outer: inner: root",
+// walking next links and joining non-empty texts with ": ". The final
+// non-*errorChain link, if any, contributes its Error() text.
+func chainText(err error) string {
+	var parts []string
+	for {
+		ec, ok := err.(*errorChain)
+		if !ok {
+			if err != nil {
+				if t := err.Error(); t != "" {
+					parts = append(parts, t)
+				}
+			}
+			break
+		}
+		if ec.text != "" {
+			parts = append(parts, ec.text)
+		}
+		if ec.next == nil {
+			break
+		}
+		err = ec.next
+	}
+	return strings.Join(parts, ": ")
+}
+
+// Format implements fmt.Formatter so that %s and %v print the chain as
+// "outer: inner: root", and %+v additionally prints each link's
+// file:line and, when captured, its full stack, indented underneath.
+func (e *errorChain) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			e.formatVerbose(s)
+			return
+		}
+		io.WriteString(s, chainText(e))
+	case 's':
+		io.WriteString(s, chainText(e))
+	case 'q':
+		fmt.Fprintf(s, "%q", chainText(e))
+	}
+}
+
+func (e *errorChain) formatVerbose(s fmt.State) {
+	var err error = e
+	first := true
+	for {
+		ec, ok := err.(*errorChain)
+		if !ok {
+			if err != nil {
+				if !first {
+					io.WriteString(s, "\n")
+				}
+				io.WriteString(s, err.Error())
+			}
+			return
+		}
+		if header := linkHeader(ec); header != "" {
+			if !first {
+				io.WriteString(s, "\n")
+			}
+			first = false
+			fmt.Fprintf(s, "%s\n    %s:%d", header, ec.pc.File(), ec.pc.Line())
+			for _, f := range Stack(ec) {
+				fmt.Fprintf(s, "\n        %s\n            %s:%d", f.Function, f.File, f.Line)
+			}
+		}
+		if ec.next == nil {
+			return
+		}
+		err = ec.next
+	}
+}
+
+// linkHeader returns the text shown for a single link in %+v. Links
+// created by New/Wrap/... use their own text; links with no text of
+// their own (With/WithFields/WithKind) instead describe the kind
+// and/or fields they attached, so they don't print as a blank block.
+func linkHeader(ec *errorChain) string {
+	if ec.text != "" {
+		return ec.text
+	}
+	var parts []string
+	if ec.kind != "" {
+		parts = append(parts, fmt.Sprintf("kind=%s", ec.kind))
+	}
+	if len(ec.fields) > 0 {
+		kv := make([]string, len(ec.fields))
+		for i, f := range ec.fields {
+			kv[i] = fmt.Sprintf("%s=%v", f.key, f.value)
+		}
+		parts = append(parts, strings.Join(kv, " "))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
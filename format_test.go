@@ -0,0 +1,94 @@
+// Copyright 2022 Kami
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/goentf/errors"
+)
+
+func TestFormatChain(t *testing.T) {
+	root := errors.New("root")
+	mid := errors.New("mid", root)
+	top := errors.New("top", mid)
+
+	if got := fmt.Sprintf("%v", top); got != "top: mid: root" {
+		t.Errorf("unexpected %%v output: %q", got)
+	}
+	if got := fmt.Sprintf("%s", top); got != "top: mid: root" {
+		t.Errorf("unexpected %%s output: %q", got)
+	}
+}
+
+func TestFormatVerbose(t *testing.T) {
+	root := errors.New("root")
+	top := errors.New("top", root)
+
+	got := fmt.Sprintf("%+v", top)
+	if !strings.Contains(got, "top") || !strings.Contains(got, "root") {
+		t.Errorf("expected %%+v to mention every link, got %q", got)
+	}
+	if !strings.Contains(got, "format_test.go") {
+		t.Errorf("expected %%+v to include the file name, got %q", got)
+	}
+}
+
+func TestFormatVerboseFieldOnlyLink(t *testing.T) {
+	root := errors.New("root")
+	tagged := errors.With(root, "user", 42)
+
+	got := fmt.Sprintf("%+v", tagged)
+	if !strings.Contains(got, "user=42") {
+		t.Errorf("expected %%+v to describe the field-only link, got %q", got)
+	}
+	if !strings.Contains(got, "[user=42]\n") || !strings.Contains(got, "\nroot\n") {
+		t.Errorf("expected a file:line block for both the field-only link and root, got %q", got)
+	}
+}
+
+func TestStack(t *testing.T) {
+	err := errors.New("boom")
+	frames := errors.Stack(err)
+	if len(frames) == 0 {
+		t.Fatal("expected a captured stack")
+	}
+	if !strings.Contains(frames[0].Function, "TestStack") {
+		t.Errorf("expected the top frame to be the test function, got %q", frames[0].Function)
+	}
+}
+
+func TestStackDisabled(t *testing.T) {
+	old := errors.MaxStackDepth
+	errors.MaxStackDepth = 0
+	defer func() { errors.MaxStackDepth = old }()
+
+	err := errors.New("boom")
+	if frames := errors.Stack(err); frames != nil {
+		t.Errorf("expected no stack when MaxStackDepth is 0, got %d frames", len(frames))
+	}
+}
+
+func TestStackTrace(t *testing.T) {
+	root := errors.New("root")
+	top := errors.New("top", root)
+
+	trace := errors.StackTrace(top)
+	if len(trace) != 2 {
+		t.Fatalf("expected one PCounter per link, got %d", len(trace))
+	}
+}
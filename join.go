@@ -0,0 +1,113 @@
+// Copyright 2022 Kami
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MaxJoinedMessages caps how many of a multiError's messages are
+// spelled out by Error() before the rest are collapsed into a count.
+var MaxJoinedMessages = 3
+
+// multiError aggregates several errors that happened in parallel, such
+// as closing multiple resources or fanning out to several RPCs.
+type multiError struct {
+	errs []error
+}
+
+// Join returns an error that wraps every non-nil error in errs. Nils
+// are filtered out; Join returns nil if none remain, the error itself
+// if exactly one remains, and an aggregate otherwise.
+func Join(errs ...error) error {
+	var filtered []error
+	for _, err := range errs {
+		if err != nil {
+			filtered = append(filtered, err)
+		}
+	}
+	switch len(filtered) {
+	case 0:
+		return nil
+	case 1:
+		return filtered[0]
+	default:
+		return &multiError{errs: filtered}
+	}
+}
+
+// Append joins dst with errs, flattening dst into the result if it is
+// already a multiError rather than nesting another layer.
+func Append(dst error, errs ...error) error {
+	if m, ok := dst.(*multiError); ok {
+		return Join(append(append([]error{}, m.errs...), errs...)...)
+	}
+	return Join(append([]error{dst}, errs...)...)
+}
+
+func (m *multiError) Error() string {
+	msgs := make([]string, len(m.errs))
+	for i, err := range m.errs {
+		msgs[i] = err.Error()
+	}
+	shown := msgs
+	var more int
+	if limit := MaxJoinedMessages; limit > 0 && len(shown) > limit {
+		shown, more = msgs[:limit], len(msgs)-limit
+	}
+	b := strings.Join(shown, "; ")
+	if more > 0 {
+		b += fmt.Sprintf("; ... %d more", more)
+	}
+	return fmt.Sprintf("%d errors: [%s]", len(m.errs), b)
+}
+
+// Unwrap exposes every branch so the stdlib errors.Is/As, and this
+// package's ForCauses/OneCauseOf, descend into all of them. Cause only
+// follows Unwrap() error, so it returns nil for a multiError.
+func (m *multiError) Unwrap() []error {
+	return m.errs
+}
+
+// Format implements fmt.Formatter: %s/%v render the same summary as
+// Error, and %+v prints each branch on its own indented block using
+// that branch's own Format/Error.
+func (m *multiError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			for i, err := range m.errs {
+				if i > 0 {
+					io.WriteString(s, "\n")
+				}
+				fmt.Fprintf(s, "[%d] %s", i, indent(fmt.Sprintf("%+v", err)))
+			}
+			return
+		}
+		io.WriteString(s, m.Error())
+	case 's':
+		io.WriteString(s, m.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", m.Error())
+	}
+}
+
+// indent pushes every line after the first in over by four spaces, so
+// nested branches read as indented blocks under their "[i] " header.
+func indent(s string) string {
+	return strings.ReplaceAll(s, "\n", "\n    ")
+}
@@ -0,0 +1,103 @@
+// Copyright 2022 Kami
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/goentf/errors"
+)
+
+func TestJoinFiltersNil(t *testing.T) {
+	if err := errors.Join(nil, nil); err != nil {
+		t.Errorf("expected Join of only nils to be nil, got %v", err)
+	}
+}
+
+func TestJoinSingle(t *testing.T) {
+	err1 := errors.New("boom")
+	if got := errors.Join(nil, err1, nil); got != err1 {
+		t.Errorf("expected Join with one non-nil error to return it unwrapped, got %v", got)
+	}
+}
+
+func TestJoinMultiple(t *testing.T) {
+	err1 := errors.New("first")
+	err2 := errors.New("second")
+	joined := errors.Join(err1, err2)
+
+	if !errors.Is(joined, err1) || !errors.Is(joined, err2) {
+		t.Errorf("expected Is to find both branches")
+	}
+	if got, want := joined.Error(), "2 errors: [first; second]"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestJoinTruncatesMessages(t *testing.T) {
+	old := errors.MaxJoinedMessages
+	errors.MaxJoinedMessages = 1
+	defer func() { errors.MaxJoinedMessages = old }()
+
+	joined := errors.Join(errors.New("a"), errors.New("b"), errors.New("c"))
+	if got, want := joined.Error(), "3 errors: [a; ... 2 more]"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestAppendFlattens(t *testing.T) {
+	joined := errors.Join(errors.New("a"), errors.New("b"))
+	appended := errors.Append(joined, errors.New("c"))
+	if got, want := appended.Error(), "3 errors: [a; b; c]"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestForCausesDescendsBothBranches(t *testing.T) {
+	err1 := errors.New("first")
+	err2 := errors.New("second")
+	joined := errors.Join(err1, err2)
+
+	var seen []error
+	errors.ForCauses(joined, func(e error) {
+		seen = append(seen, e)
+	})
+	if len(seen) != 3 {
+		t.Fatalf("expected the joined error plus both branches, got %d", len(seen))
+	}
+}
+
+func TestForCausesDedupsSharedBranch(t *testing.T) {
+	shared := errors.New("shared")
+	joined := errors.Join(shared, shared)
+
+	var seen []error
+	errors.ForCauses(joined, func(e error) {
+		seen = append(seen, e)
+	})
+	if len(seen) != 2 {
+		t.Fatalf("expected the joined error plus the shared branch visited once, got %d", len(seen))
+	}
+}
+
+func TestJoinFormatVerbose(t *testing.T) {
+	joined := errors.Join(errors.New("first"), errors.New("second"))
+	got := strings.TrimSpace(fmt.Sprintf("%+v", joined))
+	if !strings.Contains(got, "[0] first") || !strings.Contains(got, "[1] second") {
+		t.Errorf("expected %%+v to show both branches, got %q", got)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2022 Kami
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import "github.com/goentf/runpoint"
+
+// Kind classifies what went wrong, independent of the error's message,
+// so callers can translate domain errors into HTTP/gRPC status codes
+// without string matching.
+type Kind string
+
+const (
+	// KindNotFound means the requested thing does not exist.
+	KindNotFound Kind = "not found"
+	// KindUnauthorized means the caller is not allowed to do this.
+	KindUnauthorized Kind = "unauthorized"
+	// KindAlreadyExists means the thing being created already exists.
+	KindAlreadyExists Kind = "already exists"
+	// KindBadRequest means the caller's input was invalid.
+	KindBadRequest Kind = "bad request"
+	// KindTimeout means the operation did not complete in time.
+	KindTimeout Kind = "timeout"
+	// KindNotImplemented means the operation is recognised but not supported.
+	KindNotImplemented Kind = "not implemented"
+	// KindInternal means something went wrong that the caller can't act on.
+	KindInternal Kind = "internal"
+)
+
+// NewNotFound returns a KindNotFound error in the format of the given text.
+func NewNotFound(text string, cause ...error) error {
+	return newKind(KindNotFound, text, cause...)
+}
+
+// NewUnauthorized returns a KindUnauthorized error in the format of the given text.
+func NewUnauthorized(text string, cause ...error) error {
+	return newKind(KindUnauthorized, text, cause...)
+}
+
+// NewAlreadyExists returns a KindAlreadyExists error in the format of the given text.
+func NewAlreadyExists(text string, cause ...error) error {
+	return newKind(KindAlreadyExists, text, cause...)
+}
+
+// NewBadRequest returns a KindBadRequest error in the format of the given text.
+func NewBadRequest(text string, cause ...error) error {
+	return newKind(KindBadRequest, text, cause...)
+}
+
+// NewTimeout returns a KindTimeout error in the format of the given text.
+func NewTimeout(text string, cause ...error) error {
+	return newKind(KindTimeout, text, cause...)
+}
+
+// NewNotImplemented returns a KindNotImplemented error in the format of the given text.
+func NewNotImplemented(text string, cause ...error) error {
+	return newKind(KindNotImplemented, text, cause...)
+}
+
+// NewInternal returns a KindInternal error in the format of the given text.
+func NewInternal(text string, cause ...error) error {
+	return newKind(KindInternal, text, cause...)
+}
+
+func newKind(k Kind, text string, cause ...error) error {
+	var next error
+	if len(cause) == 1 {
+		next = cause[0]
+	}
+	return &errorChain{
+		text:  text,
+		next:  next,
+		kind:  k,
+		pc:    runpoint.PC(2),
+		stack: captureStack(3),
+	}
+}
+
+// WithKind tags err with k, so KindOf(err) and the Is<Kind> predicates can
+// find it. It returns nil if err is nil.
+func WithKind(err error, k Kind) error {
+	if err == nil {
+		return nil
+	}
+	return &errorChain{
+		next:  err,
+		kind:  k,
+		pc:    runpoint.PC(1),
+		stack: captureStack(2),
+	}
+}
+
+// KindOf returns the first Kind found while walking err's chain, or ""
+// if none of the links carry one.
+func KindOf(err error) Kind {
+	var k Kind
+	ForCauses(err, func(e error) {
+		if k != "" {
+			return
+		}
+		if ec, ok := e.(*errorChain); ok && ec.kind != "" {
+			k = ec.kind
+		}
+	})
+	return k
+}
+
+// hasKind reports whether any link in err's chain carries k. Errors
+// are commonly re-tagged with WithKind as they bubble up, so a kind
+// further down the chain must still match.
+func hasKind(err error, k Kind) bool {
+	found := false
+	ForCauses(err, func(e error) {
+		if found {
+			return
+		}
+		if ec, ok := e.(*errorChain); ok && ec.kind == k {
+			found = true
+		}
+	})
+	return found
+}
+
+// IsNotFound reports whether any link in err's chain carries KindNotFound.
+func IsNotFound(err error) bool { return hasKind(err, KindNotFound) }
+
+// IsUnauthorized reports whether any link in err's chain carries KindUnauthorized.
+func IsUnauthorized(err error) bool { return hasKind(err, KindUnauthorized) }
+
+// IsAlreadyExists reports whether any link in err's chain carries KindAlreadyExists.
+func IsAlreadyExists(err error) bool { return hasKind(err, KindAlreadyExists) }
+
+// IsBadRequest reports whether any link in err's chain carries KindBadRequest.
+func IsBadRequest(err error) bool { return hasKind(err, KindBadRequest) }
+
+// IsTimeout reports whether any link in err's chain carries KindTimeout.
+func IsTimeout(err error) bool { return hasKind(err, KindTimeout) }
+
+// IsNotImplemented reports whether any link in err's chain carries KindNotImplemented.
+func IsNotImplemented(err error) bool { return hasKind(err, KindNotImplemented) }
+
+// IsInternal reports whether any link in err's chain carries KindInternal.
+func IsInternal(err error) bool { return hasKind(err, KindInternal) }
@@ -0,0 +1,91 @@
+// Copyright 2022 Kami
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goentf/errors"
+)
+
+func TestNewNotFoundKind(t *testing.T) {
+	err := errors.NewNotFound("user 42")
+	if !errors.IsNotFound(err) {
+		t.Errorf("expected IsNotFound to be true")
+	}
+	if errors.KindOf(err) != errors.KindNotFound {
+		t.Errorf("expected Kind to be KindNotFound, got %q", errors.KindOf(err))
+	}
+}
+
+func TestKindWalksChain(t *testing.T) {
+	root := errors.NewUnauthorized("no access")
+	wrapped := errors.New("handler failed", root)
+	if !errors.IsUnauthorized(wrapped) {
+		t.Errorf("expected IsUnauthorized to walk through to the wrapped cause")
+	}
+}
+
+func TestWithKind(t *testing.T) {
+	base := errors.New("plain")
+	tagged := errors.WithKind(base, errors.KindTimeout)
+	if !errors.IsTimeout(tagged) {
+		t.Errorf("expected WithKind to tag the error with KindTimeout")
+	}
+	if !errors.Is(tagged, base) {
+		t.Errorf("expected WithKind's wrapper to still chain to the original error")
+	}
+}
+
+func TestIsXFindsInnerKindUnderDifferentOuterKind(t *testing.T) {
+	inner := errors.NewNotFound("x")
+	outer := errors.WithKind(inner, errors.KindInternal)
+	if !errors.IsNotFound(outer) {
+		t.Errorf("expected IsNotFound to find KindNotFound on an inner link even though the outer link carries KindInternal")
+	}
+	if !errors.IsInternal(outer) {
+		t.Errorf("expected IsInternal to still match the outer link")
+	}
+}
+
+func TestIsXFindsInnerKindThroughPlainWrap(t *testing.T) {
+	inner := errors.NewTimeout("slow")
+	wrapped := errors.New("handler", inner)
+	outer := errors.WithKind(wrapped, errors.KindBadRequest)
+	if !errors.IsTimeout(outer) {
+		t.Errorf("expected IsTimeout to find KindTimeout past an outer KindBadRequest link")
+	}
+}
+
+func TestWithKindNil(t *testing.T) {
+	if errors.WithKind(nil, errors.KindInternal) != nil {
+		t.Errorf("expected WithKind(nil, ...) to return nil")
+	}
+}
+
+func TestKindPCLocation(t *testing.T) {
+	err := errors.NewBadRequest("bad input")
+	if file := errors.File(err); !strings.HasSuffix(file, "kind_test.go") {
+		t.Errorf("expected the captured PC to point at kind_test.go, got %q", file)
+	}
+	frames := errors.Stack(err)
+	if len(frames) == 0 {
+		t.Fatal("expected NewBadRequest to capture a stack")
+	}
+	if !strings.Contains(frames[0].Function, "TestKindPCLocation") {
+		t.Errorf("expected the top frame to be the test function, got %q", frames[0].Function)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2022 Kami
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import (
+	"fmt"
+
+	"github.com/goentf/runpoint"
+)
+
+// Wrap returns an error whose Error() renders as "text: cause.Error()",
+// while still keeping text and cause available as distinct links for
+// Cause, ForCauses and %+v. It returns nil if cause is nil.
+func Wrap(cause error, text string) error {
+	return newWrap(cause, text)
+}
+
+// Wrapf is like Wrap but formats its text according to a format specifier.
+func Wrapf(cause error, format string, args ...any) error {
+	return newWrap(cause, fmt.Sprintf(format, args...))
+}
+
+// Annotate is an alias for Wrap, for callers coming from the
+// juju/errors convention.
+func Annotate(cause error, text string) error {
+	return newWrap(cause, text)
+}
+
+// Annotatef is an alias for Wrapf, for callers coming from the
+// juju/errors convention.
+func Annotatef(cause error, format string, args ...any) error {
+	return newWrap(cause, fmt.Sprintf(format, args...))
+}
+
+func newWrap(cause error, text string) error {
+	if cause == nil {
+		return nil
+	}
+	return &errorChain{
+		text:  text,
+		next:  cause,
+		pc:    runpoint.PC(2),
+		stack: captureStack(3),
+	}
+}
+
+// WithMessage is like Wrap but does not capture a new PC or stack,
+// making it cheap enough for hot paths that re-wrap the same error
+// at many call sites.
+func WithMessage(cause error, text string) error {
+	if cause == nil {
+		return nil
+	}
+	return &errorChain{
+		text: text,
+		next: cause,
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2022 Kami
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/goentf/errors"
+)
+
+func TestWrapNil(t *testing.T) {
+	if errors.Wrap(nil, "context") != nil {
+		t.Errorf("expected Wrap(nil, ...) to return nil")
+	}
+	if errors.Wrapf(nil, "context %d", 1) != nil {
+		t.Errorf("expected Wrapf(nil, ...) to return nil")
+	}
+	if errors.Annotate(nil, "context") != nil {
+		t.Errorf("expected Annotate(nil, ...) to return nil")
+	}
+	if errors.Annotatef(nil, "context %d", 1) != nil {
+		t.Errorf("expected Annotatef(nil, ...) to return nil")
+	}
+	if errors.WithMessage(nil, "context") != nil {
+		t.Errorf("expected WithMessage(nil, ...) to return nil")
+	}
+}
+
+func TestWrapMessage(t *testing.T) {
+	root := errors.New("disk full")
+	err := errors.Wrap(root, "save failed")
+	if got, want := err.Error(), "save failed: disk full"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if errors.Cause(err) != root {
+		t.Errorf("expected Cause to return the wrapped error")
+	}
+}
+
+func TestWrapfMessage(t *testing.T) {
+	root := errors.New("disk full")
+	err := errors.Wrapf(root, "save %s failed", "report.csv")
+	if got, want := err.Error(), "save report.csv failed: disk full"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestAnnotateMessage(t *testing.T) {
+	root := errors.New("disk full")
+	err := errors.Annotate(root, "save failed")
+	if got, want := err.Error(), "save failed: disk full"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestWrapCapturesCallSite(t *testing.T) {
+	root := errors.New("disk full")
+	err := errors.Wrap(root, "save failed")
+	if file := errors.File(err); !strings.HasSuffix(file, "wrap_test.go") {
+		t.Errorf("expected Wrap's PC to point at wrap_test.go, got %q", file)
+	}
+}
+
+func TestWithMessageNoPC(t *testing.T) {
+	root := errors.New("disk full")
+	err := errors.WithMessage(root, "save failed")
+	if got, want := err.Error(), "save failed: disk full"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if file := errors.File(err); file != "" {
+		t.Errorf("expected WithMessage not to capture a PC, got file %q", file)
+	}
+}
+
+func TestWrapFormatVerbose(t *testing.T) {
+	root := errors.New("disk full")
+	err := errors.Wrap(root, "save failed")
+	got := fmt.Sprintf("%+v", err)
+	if !strings.Contains(got, "save failed") || !strings.Contains(got, "disk full") {
+		t.Errorf("expected %%+v to show both layers, got %q", got)
+	}
+}
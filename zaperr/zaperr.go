@@ -0,0 +1,116 @@
+// Copyright 2022 Kami
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package zaperr lets github.com/goentf/errors values plug into zap's
+// structured logging without the core errors package depending on zap.
+package zaperr
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/goentf/errors"
+)
+
+// LogObject wraps err so it can be passed to zap.Object, producing a
+// rich record with message, file/line, fields, nested causes and a
+// stack trace instead of a flat message string.
+//
+//	logger.Error("op failed", zap.Object("err", zaperr.LogObject(err)))
+func LogObject(err error) zapcore.ObjectMarshaler {
+	return logObject{err: err}
+}
+
+type logObject struct {
+	err error
+}
+
+func (o logObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if o.err == nil {
+		return nil
+	}
+	enc.AddString("message", o.err.Error())
+	if file := errors.File(o.err); file != "" {
+		enc.AddString("file", file)
+		enc.AddInt("line", errors.Line(o.err))
+	}
+	for k, v := range errors.Fields(o.err) {
+		if err := enc.AddReflected(k, v); err != nil {
+			return err
+		}
+	}
+	if stack := stackString(o.err); stack != "" {
+		enc.AddString("stack", stack)
+	}
+	return enc.AddArray("causes", causesArray{err: o.err})
+}
+
+// causesArray renders every error below the outermost one as a nested
+// {message,file,line,fields} object.
+type causesArray struct {
+	err error
+}
+
+func (c causesArray) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	outer := true
+	var marshalErr error
+	errors.ForCauses(c.err, func(e error) {
+		if outer {
+			outer = false
+			return
+		}
+		if marshalErr != nil {
+			return
+		}
+		marshalErr = enc.AppendObject(causeObject{err: e})
+	})
+	return marshalErr
+}
+
+type causeObject struct {
+	err error
+}
+
+func (c causeObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("message", c.err.Error())
+	if file := errors.File(c.err); file != "" {
+		enc.AddString("file", file)
+		enc.AddInt("line", errors.Line(c.err))
+	}
+	for k, v := range errors.OwnFields(c.err) {
+		if err := enc.AddReflected(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stackString renders err's captured stack with each frame as
+// "function\n\tfile:line", frames separated by a newline.
+func stackString(err error) string {
+	frames := errors.Stack(err)
+	if len(frames) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for i, f := range frames {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "%s\n\t%s:%d", f.Function, f.File, f.Line)
+	}
+	return b.String()
+}
@@ -0,0 +1,94 @@
+// Copyright 2022 Kami
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zaperr_test
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/goentf/errors"
+	"github.com/goentf/errors/zaperr"
+)
+
+func TestLogObject(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	logger := zap.New(core)
+
+	root := errors.NewNotFound("user 42")
+	root = errors.With(root, "user_id", 42)
+	err := errors.Wrap(root, "handler failed")
+
+	logger.Error("op failed", zap.Object("err", zaperr.LogObject(err)))
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	errField, ok := fields["err"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected err field to be an object, got %T", fields["err"])
+	}
+	if errField["message"] != "handler failed: user 42" {
+		t.Errorf("unexpected message: %v", errField["message"])
+	}
+	if errField["user_id"] != 42 {
+		t.Errorf("expected merged user_id field, got %v", errField["user_id"])
+	}
+	causes, ok := errField["causes"].([]interface{})
+	if !ok || len(causes) == 0 {
+		t.Fatalf("expected a non-empty causes array, got %v", errField["causes"])
+	}
+}
+
+func TestLogObjectCauseFieldsAreNotChainMerged(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	logger := zap.New(core)
+
+	deep := errors.New("root cause")
+	deep = errors.With(deep, "deep_field", 1)
+	mid := errors.Wrap(deep, "middle")
+	err := errors.Wrap(mid, "outer")
+
+	logger.Error("op failed", zap.Object("err", zaperr.LogObject(err)))
+
+	fields := logs.All()[0].ContextMap()
+	errField := fields["err"].(map[string]interface{})
+	causes := errField["causes"].([]interface{})
+
+	var withDeepField int
+	for _, c := range causes {
+		cause := c.(map[string]interface{})
+		if _, ok := cause["deep_field"]; ok {
+			withDeepField++
+		}
+	}
+	if withDeepField != 1 {
+		t.Errorf("expected deep_field to appear on exactly 1 cause entry, got %d", withDeepField)
+	}
+}
+
+func TestLogObjectNil(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	logger := zap.New(core)
+
+	logger.Error("op failed", zap.Object("err", zaperr.LogObject(nil)))
+
+	if len(logs.All()) != 1 {
+		t.Fatalf("expected logging a nil error not to fail")
+	}
+}